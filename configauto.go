@@ -0,0 +1,135 @@
+package fc
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// sourceForFilename picks the config source implementation appropriate for
+// filename's extension: .json, .yaml/.yml, .toml and .env get their
+// matching source, anything else falls back to the plain key/value format.
+func sourceForFilename(filename string) Source {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".json":
+		return WithJSONFile(filename)
+	case ".yaml", ".yml":
+		return WithYAMLFile(filename)
+	case ".toml":
+		return WithTOMLFile(filename)
+	case ".env":
+		return WithEnvFile(filename)
+	default:
+		return WithConfigFile(filename)
+	}
+}
+
+// AutoSource wraps a configuration file source, picking its underlying
+// implementation by inspecting the file's extension
+type AutoSource struct {
+	filename   string
+	underlying Source
+}
+
+// WithConfigFileAuto defines a new configuration source from the specified
+// file, auto-detecting its format by extension
+func WithConfigFileAuto(filename string) *AutoSource {
+	return &AutoSource{
+		filename:   filename,
+		underlying: sourceForFilename(filename),
+	}
+}
+
+// Name returns the underlying source's name for usage
+func (a *AutoSource) Name() string {
+	return a.underlying.Name()
+}
+
+// Loc forwards to the underlying source's Loc
+func (a *AutoSource) Loc(key string) string {
+	return a.underlying.Loc(key)
+}
+
+// Get forwards to the underlying source's Get
+func (a *AutoSource) Get(key string) ([]string, error) {
+	return a.underlying.Get(key)
+}
+
+// Keys forwards to the underlying source's Keys, if it implements
+// KeyLister, implementing KeyLister itself
+func (a *AutoSource) Keys() ([]string, error) {
+	lister, ok := a.underlying.(KeyLister)
+	if !ok {
+		return nil, nil
+	}
+	return lister.Keys()
+}
+
+// AutoFlagSource is the flag-driven counterpart to AutoSource: it picks its
+// underlying implementation once the flag naming its file has been parsed
+type AutoFlagSource struct {
+	flagName   string
+	underlying Source
+}
+
+// WithConfigFileFlagAuto defines a new configuration source from the file
+// named by the specified flag, auto-detecting its format by extension once
+// the flag has been parsed
+func WithConfigFileFlagAuto(flag string) *AutoFlagSource {
+	return &AutoFlagSource{
+		flagName: flag,
+	}
+}
+
+// Name returns a useful name for the auto-detecting flag source for usage
+func (af *AutoFlagSource) Name() string {
+	return fmt.Sprintf("auto-detected configuration file defined by %q flag", af.flagName)
+}
+
+// Loc forwards to the underlying source's Loc, once it has been determined
+func (af *AutoFlagSource) Loc(key string) string {
+	if af.underlying == nil {
+		return fmt.Sprintf("(file not yet determined), key %q", key)
+	}
+	return af.underlying.Loc(key)
+}
+
+// Get forwards to the underlying source's Get, once it has been determined
+func (af *AutoFlagSource) Get(key string) ([]string, error) {
+	if af.underlying == nil {
+		return nil, ErrMissing
+	}
+	return af.underlying.Get(key)
+}
+
+// FlagNeeded returns the name of the flag that the AutoFlagSource will use
+// to determine which file, and therefore which format, to pull
+// configuration from
+func (af *AutoFlagSource) FlagNeeded() string {
+	return af.flagName
+}
+
+// WithFlagValue selects the underlying source implementation based on the
+// filename's extension
+func (af *AutoFlagSource) WithFlagValue(value string) error {
+	if value == "" {
+		return errors.New("AutoFlagSource given an empty string")
+	}
+	af.underlying = sourceForFilename(value)
+	return nil
+}
+
+// Keys forwards to the underlying source's Keys, if it implements
+// KeyLister, implementing KeyLister itself
+func (af *AutoFlagSource) Keys() ([]string, error) {
+	if af.underlying == nil {
+		return nil, nil
+	}
+	lister, ok := af.underlying.(KeyLister)
+	if !ok {
+		return nil, nil
+	}
+	return lister.Keys()
+}
@@ -10,13 +10,18 @@ import (
 // prefix. By convention, the prefix and flag name will be converted to
 // UPPERCASE, and all dashes will be converted to underscores.
 type EnvSource struct {
-	prefix string
+	prefix    string
+	hasPrefix bool
 }
 
 // WithEnv returns a new source that pulls from os.ENV with the specified
 // prefix, or from the entire environment if no prefix is provided
 func WithEnv(prefix string) *EnvSource {
-	return &EnvSource{prefix: strings.ToUpper(prefix) + "_"}
+	e := &EnvSource{hasPrefix: prefix != ""}
+	if e.hasPrefix {
+		e.prefix = strings.ToUpper(prefix) + "_"
+	}
+	return e
 }
 
 // Get attempts to retrieve a flag from os.ENV
@@ -31,11 +36,7 @@ func (e *EnvSource) Get(key string) ([]string, error) {
 
 // Loc returns the computed environment name for the flag
 func (e *EnvSource) Loc(key string) string {
-	key = strings.ToUpper(key)
-	if e.prefix != "" {
-		key = e.prefix + key
-	}
-	return envVarReplacer.Replace(key)
+	return envKeyFor(e.prefix, key)
 }
 
 // Name returns a useful name for the EnvSource for usage
@@ -46,8 +47,49 @@ func (e *EnvSource) Name() string {
 	return fmt.Sprintf("environment variables with the prefix %q", e.prefix)
 }
 
+// Keys returns every key in the environment matching this source's prefix,
+// implementing KeyLister. Keys are derived by stripping the prefix and
+// lowercasing the remainder, replacing underscores with dashes; MappedKeys
+// derives a flag's key the same way from its Loc name. A prefix-less
+// EnvSource pulls from the entire process environment, which is not
+// meaningfully enumerable as configuration, so it reports no keys and is
+// effectively skipped by ParseStrict.
+func (e *EnvSource) Keys() ([]string, error) {
+	if !e.hasPrefix {
+		return nil, nil
+	}
+	var keys []string
+	for _, kv := range os.Environ() {
+		name, _, _ := strings.Cut(kv, "=")
+		if !strings.HasPrefix(name, e.prefix) {
+			continue
+		}
+		name = strings.TrimPrefix(name, e.prefix)
+		keys = append(keys, strings.ToLower(strings.ReplaceAll(name, "_", "-")))
+	}
+	return keys, nil
+}
+
+// MappedKeys returns the key Keys reports for the environment variable
+// flagName is read from, implementing KeyMapper
+func (e *EnvSource) MappedKeys(flagName string) []string {
+	name := strings.TrimPrefix(envKeyFor(e.prefix, flagName), e.prefix)
+	return []string{strings.ToLower(strings.ReplaceAll(name, "_", "-"))}
+}
+
 var envVarReplacer = strings.NewReplacer(
 	"-", "_",
 	".", "_",
 	"/", "_",
 )
+
+// envKeyFor computes the environment variable name for a flag key given an
+// (already uppercased, trailing-underscore) prefix, converting to uppercase
+// and replacing dashes, dots and slashes with underscores
+func envKeyFor(prefix, key string) string {
+	key = strings.ToUpper(key)
+	if prefix != "" {
+		key = prefix + key
+	}
+	return envVarReplacer.Replace(key)
+}
@@ -0,0 +1,189 @@
+package fc
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// EnvFileSource is a source for configuration stored in a .env-style file.
+// Each non-empty, non-comment line is split on the first "=" into a name
+// and a value; both are trimmed of surrounding whitespace. Values wrapped
+// in double quotes have escape sequences such as \n, \t, \" and \\
+// expanded; values wrapped in single quotes are taken literally. An
+// optional prefix may be given, stripped using the same uppercase and
+// dash/dot/slash-to-underscore conventions as EnvSource, so a file
+// containing APP_PORT=8080 can be read alongside WithEnv("APP").
+type EnvFileSource struct {
+	filename string
+	prefix   string
+	m        map[string][]string
+}
+
+// WithEnvFile defines a new configuration source from the specified
+// .env-style file. An optional prefix may be given, matching the behavior
+// of WithEnv.
+func WithEnvFile(filename string, prefix ...string) *EnvFileSource {
+	return &EnvFileSource{
+		filename: filename,
+		prefix:   envFilePrefix(prefix),
+	}
+}
+
+// Name returns a useful name for the env file source for usage
+func (e *EnvFileSource) Name() string {
+	return fmt.Sprintf("env file %q", e.filename)
+}
+
+// Loc returns the key as it is expected to appear in the env file
+func (e *EnvFileSource) Loc(key string) string {
+	return fmt.Sprintf("%s, key %q", e.filename, envKeyFor(e.prefix, key))
+}
+
+// Get returns the stringified value(s) stored at the specified key in the
+// env file
+func (e *EnvFileSource) Get(key string) ([]string, error) {
+	if e.m == nil {
+		if err := e.init(); err != nil {
+			return nil, err
+		}
+	}
+	values, ok := e.m[envKeyFor(e.prefix, key)]
+	if !ok {
+		return nil, ErrMissing
+	}
+	return values, nil
+}
+
+func (e *EnvFileSource) init() error {
+	e.m = make(map[string][]string)
+
+	ef, err := os.Open(e.filename)
+	if err != nil {
+		return err
+	}
+	defer ef.Close()
+
+	s := bufio.NewScanner(ef)
+	for s.Scan() {
+		line := strings.TrimSpace(s.Text())
+		if line == "" || line[0] == '#' {
+			continue
+		}
+
+		index := strings.IndexRune(line, '=')
+		if index < 0 {
+			continue
+		}
+		name := strings.TrimSpace(line[:index])
+		raw := strings.TrimSpace(line[index+1:])
+
+		value, quoted, err := unquoteEnvValue(raw)
+		if err != nil {
+			return errors.Wrapf(err, "error parsing %s", e.filename)
+		}
+
+		if quoted {
+			// Quoting means "take this value literally" — a quoted comma
+			// is part of the value, not a multi-value separator.
+			e.m[name] = []string{value}
+		} else {
+			e.m[name] = strings.Split(value, ",")
+		}
+	}
+	return s.Err()
+}
+
+// unquoteEnvValue expands escape sequences in a double-quoted value, takes
+// a single-quoted value literally, and otherwise returns the value as-is.
+// The returned bool reports whether value was quoted.
+func unquoteEnvValue(value string) (string, bool, error) {
+	switch {
+	case len(value) >= 2 && value[0] == '"' && value[len(value)-1] == '"':
+		unquoted, err := strconv.Unquote(value)
+		return unquoted, true, err
+	case len(value) >= 2 && value[0] == '\'' && value[len(value)-1] == '\'':
+		return value[1 : len(value)-1], true, nil
+	default:
+		return value, false, nil
+	}
+}
+
+func envFilePrefix(prefix []string) string {
+	if len(prefix) == 0 || prefix[0] == "" {
+		return ""
+	}
+	return strings.ToUpper(prefix[0]) + "_"
+}
+
+// Keys returns every key held by the env file matching this source's
+// prefix, implementing KeyLister. Keys are derived the same way as
+// EnvSource.Keys: the prefix is stripped and the remainder is lowercased,
+// with underscores replaced by dashes.
+func (e *EnvFileSource) Keys() ([]string, error) {
+	if e.m == nil {
+		if err := e.init(); err != nil {
+			return nil, err
+		}
+	}
+	keys := make([]string, 0, len(e.m))
+	for name := range e.m {
+		if e.prefix != "" {
+			if !strings.HasPrefix(name, e.prefix) {
+				continue
+			}
+			name = strings.TrimPrefix(name, e.prefix)
+		}
+		keys = append(keys, strings.ToLower(strings.ReplaceAll(name, "_", "-")))
+	}
+	return keys, nil
+}
+
+// MappedKeys returns the key Keys reports for the env file entry flagName
+// is read from, implementing KeyMapper
+func (e *EnvFileSource) MappedKeys(flagName string) []string {
+	name := strings.TrimPrefix(envKeyFor(e.prefix, flagName), e.prefix)
+	return []string{strings.ToLower(strings.ReplaceAll(name, "_", "-"))}
+}
+
+// EnvFileFlagSource is an EnvFileSource that uses a flag value to define
+// the file to pull configuration from
+type EnvFileFlagSource struct {
+	*EnvFileSource
+	flagName string
+}
+
+// WithEnvFileFlag defines a new configuration source from the env file
+// named by the specified flag. An optional prefix may be given, matching
+// the behavior of WithEnv.
+func WithEnvFileFlag(flag string, prefix ...string) *EnvFileFlagSource {
+	return &EnvFileFlagSource{
+		EnvFileSource: &EnvFileSource{prefix: envFilePrefix(prefix)},
+		flagName:      flag,
+	}
+}
+
+// Name returns a useful name for the env file flag source for usage
+func (ef *EnvFileFlagSource) Name() string {
+	return fmt.Sprintf("env file defined by %q flag", ef.flagName)
+}
+
+// FlagNeeded returns the name of the flag that the EnvFileFlagSource will
+// use to determine which file to pull configuration from
+func (ef *EnvFileFlagSource) FlagNeeded() string {
+	return ef.flagName
+}
+
+// WithFlagValue will set the filename the EnvFileFlagSource will pull
+// configuration from
+func (ef *EnvFileFlagSource) WithFlagValue(value string) error {
+	ef.EnvFileSource.filename = value
+	if value == "" {
+		return errors.New("EnvFileFlagSource given an empty string")
+	}
+	return nil
+}
@@ -35,6 +35,32 @@ type FlagSource interface {
 	WithFlagValue(string) error
 }
 
+// KeyLister is an optional interface a Source can implement to enumerate
+// every key it holds, so that ParseStrict can detect keys that don't
+// correspond to any defined flag
+type KeyLister interface {
+	// Keys returns every key held by the source
+	Keys() ([]string, error)
+}
+
+// KeyMapper is an optional interface a Source can implement when the key it
+// actually queries for a flag diverges from the flag's own name (see
+// WithKeyFunc/WithAlias). ParseStrict consults it to map defined flags to
+// the keys that should be considered valid, instead of comparing raw
+// source keys against flag names directly.
+type KeyMapper interface {
+	// MappedKeys returns every key that should be treated as satisfying
+	// flagName
+	MappedKeys(flagName string) []string
+}
+
+// cmdlineFlags records, per FlagSet, which flags were set on the actual
+// command line during ParseArgs — as opposed to by a config source, which
+// also uses fs.Set and so is otherwise indistinguishable from fs.Visit
+// afterwards. Watch consults this so a live reload doesn't clobber a value
+// the user explicitly passed on the command line.
+var cmdlineFlags = map[*flag.FlagSet]map[string]bool{}
+
 // ParseArgs parses the provided arguments with the given FlagSet and sources,
 // starting with the commandline flags and progressing through all given
 // sources in decreasing priority order until a value is found
@@ -49,6 +75,12 @@ func ParseArgs(args []string, fs *flag.FlagSet, sources ...Source) error {
 		found[f.Name] = true
 	})
 
+	fromCmdline := make(map[string]bool, len(found))
+	for name := range found {
+		fromCmdline[name] = true
+	}
+	cmdlineFlags[fs] = fromCmdline
+
 	// Initialize any FlagSources
 	for _, source := range sources {
 		if fls, ok := source.(FlagSource); ok {
@@ -0,0 +1,20 @@
+package fc
+
+// flattenMap recursively flattens nested maps into a single-level map whose
+// keys are dotted paths (e.g. "server.port"). Non-map values, including
+// slices, are copied through unchanged. Used by sources whose underlying
+// format supports nested tables/objects (YAML, TOML) to present them as flat
+// flag-addressable keys.
+func flattenMap(prefix string, in map[string]interface{}, out map[string]interface{}) {
+	for k, v := range in {
+		key := k
+		if prefix != "" {
+			key = prefix + "." + k
+		}
+		if nested, ok := v.(map[string]interface{}); ok {
+			flattenMap(key, nested, out)
+			continue
+		}
+		out[key] = v
+	}
+}
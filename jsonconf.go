@@ -1,10 +1,12 @@
 package fc
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/pkg/errors"
 )
@@ -12,10 +14,14 @@ import (
 // JSONSource is a source for config files in JSON format. Input should be
 // an object. The object's keys are treated as flag names, and the object's
 // values as flag values. If the value is an array, the flag will be set
-// multiple times.
+// multiple times. A flag named "db.host" (or "db/host") is resolved by
+// walking the document: each dot- or slash-separated segment descends into
+// an object by key or, for a numeric segment, into an array by index. This
+// lets a single JSON file configure deeply nested services without
+// flattening the schema by hand.
 type JSONSource struct {
 	filename string
-	m        map[string]interface{}
+	doc      interface{}
 }
 
 // WithJSONFile defines a new configuration source from the specified JSON file
@@ -35,27 +41,43 @@ func (j *JSONSource) Loc(key string) string {
 	return fmt.Sprintf("%s, key %q", j.filename, key)
 }
 
-// Get returns the stringfied value stored at the specified key in the JSON file
+// Get returns the stringfied value stored at the specified key in the JSON
+// file. key is first matched as a literal top-level key, so an object like
+// {"db.host": "x"} is still addressable as "db.host"; if no such key
+// exists, key is split on "." or "/" and resolved by walking the document,
+// descending into nested objects and arrays.
 func (j *JSONSource) Get(key string) ([]string, error) {
-	if j.m == nil {
+	if j.doc == nil {
 		if err := j.init(); err != nil {
 			return nil, err
 		}
 	}
-	_, ok := j.m[key]
+	val, ok := topLevelLookup(j.doc, key)
+	if !ok {
+		val, ok = lookupPath(j.doc, splitPath(key))
+	}
 	if !ok {
 		return nil, ErrMissing
 	}
-	values, err := stringifySlice(j.m[key])
+	values, err := stringifySlice(val)
 	if err != nil {
 		return nil, errors.Wrap(err, "error parsing JSON config")
 	}
 	return values, nil
 }
 
-func (j *JSONSource) init() error {
-	m := make(map[string]interface{})
+// topLevelLookup looks up key as a literal key of doc's top-level object,
+// without interpreting "." or "/" as path separators
+func topLevelLookup(doc interface{}, key string) (interface{}, bool) {
+	m, ok := doc.(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+	val, ok := m[key]
+	return val, ok
+}
 
+func (j *JSONSource) init() error {
 	jf, err := os.Open(j.filename)
 	if err != nil {
 		return err
@@ -65,14 +87,143 @@ func (j *JSONSource) init() error {
 	d := json.NewDecoder(jf)
 	// Must set UseNumber for stringifyValue to work
 	d.UseNumber()
-	err = d.Decode(&m)
-	if err != nil {
+	var doc interface{}
+	if err := d.Decode(&doc); err != nil {
 		return errors.Wrap(err, "error parsing JSON config")
 	}
-	j.m = m
+	j.doc = doc
 	return nil
 }
 
+// Keys returns every leaf key path held by the JSON document, dotted for
+// nested objects (e.g. "server.port"), implementing KeyLister
+func (j *JSONSource) Keys() ([]string, error) {
+	if j.doc == nil {
+		if err := j.init(); err != nil {
+			return nil, err
+		}
+	}
+	var keys []string
+	collectKeys("", j.doc, &keys)
+	return keys, nil
+}
+
+// splitPath splits a flag key into its path segments on "." or "/"
+func splitPath(key string) []string {
+	return strings.FieldsFunc(key, func(r rune) bool {
+		return r == '.' || r == '/'
+	})
+}
+
+// lookupPath walks doc by following path, descending into objects by key
+// and into arrays by numeric index
+func lookupPath(doc interface{}, path []string) (interface{}, bool) {
+	cur := doc
+	for _, seg := range path {
+		switch node := cur.(type) {
+		case map[string]interface{}:
+			val, ok := node[seg]
+			if !ok {
+				return nil, false
+			}
+			cur = val
+		case []interface{}:
+			idx, err := strconv.Atoi(seg)
+			if err != nil || idx < 0 || idx >= len(node) {
+				return nil, false
+			}
+			cur = node[idx]
+		default:
+			return nil, false
+		}
+	}
+	return cur, true
+}
+
+// collectKeys appends every leaf key path under node to keys, using prefix
+// as the dotted path built up so far. Objects descend by key. An array of
+// scalars is itself a leaf (it's addressable as a single multi-value flag),
+// but an array containing objects or further arrays descends by index, so
+// that keys nested inside it can still be resolved and validated.
+func collectKeys(prefix string, node interface{}, keys *[]string) {
+	switch n := node.(type) {
+	case map[string]interface{}:
+		for k, v := range n {
+			key := k
+			if prefix != "" {
+				key = prefix + "." + k
+			}
+			collectKeys(key, v, keys)
+		}
+	case []interface{}:
+		if allScalar(n) {
+			if prefix != "" {
+				*keys = append(*keys, prefix)
+			}
+			return
+		}
+		for i, v := range n {
+			key := strconv.Itoa(i)
+			if prefix != "" {
+				key = prefix + "." + key
+			}
+			collectKeys(key, v, keys)
+		}
+	default:
+		if prefix != "" {
+			*keys = append(*keys, prefix)
+		}
+	}
+}
+
+// allScalar reports whether every element of arr is a scalar (neither an
+// object nor an array)
+func allScalar(arr []interface{}) bool {
+	for _, v := range arr {
+		switch v.(type) {
+		case map[string]interface{}, []interface{}:
+			return false
+		}
+	}
+	return true
+}
+
+// Watch implements Watchable, reloading the JSON file whenever it changes
+// on disk and invoking fn for every leaf key whose value changed
+func (j *JSONSource) Watch(ctx context.Context, fn WatchFunc) error {
+	if j.doc == nil {
+		if err := j.init(); err != nil {
+			return err
+		}
+	}
+
+	return watchFile(ctx, j.filename, j.init, func() (map[string][]string, error) {
+		return flattenDoc(j.doc), nil
+	}, fn)
+}
+
+// flattenDoc stringifies every leaf key path in doc, for diffing across a
+// reload. A key whose value can't be stringified is skipped rather than
+// failing the whole reload.
+func flattenDoc(doc interface{}) map[string][]string {
+	var keys []string
+	collectKeys("", doc, &keys)
+
+	out := make(map[string][]string, len(keys))
+	for _, key := range keys {
+		val, ok := lookupPath(doc, splitPath(key))
+		if !ok {
+			continue
+		}
+		vals, err := stringifySlice(val)
+		if err != nil {
+			continue
+		}
+		out[key] = vals
+	}
+	return out
+}
+
 // JSONFlagSource is a JSONSource that uses a flag value to define the file to
 // pull configuration from
 type JSONFlagSource struct {
@@ -137,6 +288,12 @@ func stringifyValue(val interface{}) (string, error) {
 		return v.String(), nil
 	case bool:
 		return strconv.FormatBool(v), nil
+	case int:
+		return strconv.Itoa(v), nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
 	default:
 		return "", errors.Errorf("could not convert %q (type %T) to string", val, val)
 	}
@@ -0,0 +1,145 @@
+package fc
+
+import (
+	"strings"
+)
+
+// KeyFunc maps a flag name to the key a Source should actually be queried
+// with, e.g. converting "log-level" to the camelCase "logLevel" expected by
+// a JSON config file.
+type KeyFunc func(flagName string) string
+
+// KeyFuncDotted converts a dash-separated flag name (e.g. "log-level") to
+// its dotted form (e.g. "log.level"), a convention used by PlainSource and
+// nested structured sources.
+func KeyFuncDotted(flagName string) string {
+	return strings.ReplaceAll(flagName, "-", ".")
+}
+
+// KeyFuncCamelCase converts a dash-separated flag name (e.g. "log-level")
+// to camelCase (e.g. "logLevel"), a convention commonly used for JSON and
+// YAML keys.
+func KeyFuncCamelCase(flagName string) string {
+	parts := strings.Split(flagName, "-")
+	for i := 1; i < len(parts); i++ {
+		if parts[i] == "" {
+			continue
+		}
+		parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+	}
+	return strings.Join(parts, "")
+}
+
+// KeyFuncEnvStyle converts a flag name to the uppercase, underscore-
+// separated form used by EnvSource and EnvFileSource (e.g. "log-level"
+// becomes "LOG_LEVEL"). It is exposed as a preset so other sources can
+// adopt the same convention via WithKeyFunc.
+func KeyFuncEnvStyle(flagName string) string {
+	return envKeyFor("", flagName)
+}
+
+// keyMappedSource wraps a Source, rewriting each requested key with a
+// KeyFunc and/or a table of explicit aliases before delegating to the
+// wrapped Source.
+type keyMappedSource struct {
+	Source
+	keyFunc KeyFunc
+	aliases map[string][]string
+}
+
+// WithKeyFunc wraps source so that every key requested via Get/Loc is first
+// transformed by fn. This lets a single flag be addressed using a
+// source-specific naming convention, such as camelCase JSON keys or dotted
+// plain-file keys, instead of the flag's own name.
+func WithKeyFunc(source Source, fn KeyFunc) Source {
+	return wrapKeyMapped(source, fn, nil)
+}
+
+// WithAlias wraps source so that, in addition to its own name, each flag
+// named in aliases may also be populated from any of its listed alternate
+// keys. This is useful for migrating away from legacy configuration keys
+// without breaking existing config files.
+func WithAlias(source Source, aliases map[string][]string) Source {
+	return wrapKeyMapped(source, nil, aliases)
+}
+
+func wrapKeyMapped(source Source, fn KeyFunc, aliases map[string][]string) Source {
+	base := &keyMappedSource{Source: source, keyFunc: fn, aliases: aliases}
+	if fls, ok := source.(FlagSource); ok {
+		return &keyMappedFlagSource{keyMappedSource: base, FlagSource: fls}
+	}
+	return base
+}
+
+func (k *keyMappedSource) Get(flagName string) ([]string, error) {
+	key := flagName
+	if k.keyFunc != nil {
+		key = k.keyFunc(flagName)
+	}
+	vals, err := k.Source.Get(key)
+	if err != ErrMissing {
+		return vals, err
+	}
+	for _, alias := range k.aliases[flagName] {
+		vals, err := k.Source.Get(alias)
+		if err != ErrMissing {
+			return vals, err
+		}
+	}
+	return nil, ErrMissing
+}
+
+func (k *keyMappedSource) Loc(flagName string) string {
+	key := flagName
+	if k.keyFunc != nil {
+		key = k.keyFunc(flagName)
+	}
+	return k.Source.Loc(key)
+}
+
+// Keys forwards to the wrapped source's Keys, if it implements KeyLister,
+// implementing KeyLister itself
+func (k *keyMappedSource) Keys() ([]string, error) {
+	lister, ok := k.Source.(KeyLister)
+	if !ok {
+		return nil, nil
+	}
+	return lister.Keys()
+}
+
+// MappedKeys returns the key flagName is actually resolved to via keyFunc,
+// plus any aliases registered for it, implementing KeyMapper so ParseStrict
+// validates against the same keys Get actually looks up
+func (k *keyMappedSource) MappedKeys(flagName string) []string {
+	key := flagName
+	if k.keyFunc != nil {
+		key = k.keyFunc(flagName)
+	}
+	return append([]string{key}, k.aliases[flagName]...)
+}
+
+// keyMappedFlagSource is the FlagSource-preserving counterpart of
+// keyMappedSource, used when the wrapped Source is itself a FlagSource
+type keyMappedFlagSource struct {
+	*keyMappedSource
+	FlagSource
+}
+
+// Get is promoted from keyMappedSource explicitly to resolve the ambiguity
+// between keyMappedSource and the embedded FlagSource, both of which
+// provide it
+func (k *keyMappedFlagSource) Get(key string) ([]string, error) {
+	return k.keyMappedSource.Get(key)
+}
+
+// Loc is promoted from keyMappedSource explicitly, for the same reason as
+// Get
+func (k *keyMappedFlagSource) Loc(key string) string {
+	return k.keyMappedSource.Loc(key)
+}
+
+// Name is forwarded through keyMappedSource explicitly, alongside Get and
+// Loc, so all three reach the wrapped Source by the same route
+func (k *keyMappedFlagSource) Name() string {
+	return k.keyMappedSource.Name()
+}
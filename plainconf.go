@@ -2,6 +2,7 @@ package fc
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"os"
 	"strings"
@@ -91,6 +92,45 @@ func (p *PlainSource) init() error {
 	return nil
 }
 
+// Keys returns every key held by the plain config file, implementing
+// KeyLister
+func (p *PlainSource) Keys() ([]string, error) {
+	if p.m == nil {
+		if err := p.init(); err != nil {
+			return nil, err
+		}
+	}
+	keys := make([]string, 0, len(p.m))
+	for k := range p.m {
+		keys = append(keys, k)
+	}
+	return keys, nil
+}
+
+// Watch implements Watchable, reloading the plain config file whenever it
+// changes on disk and invoking fn for every key whose value changed
+func (p *PlainSource) Watch(ctx context.Context, fn WatchFunc) error {
+	if p.m == nil {
+		if err := p.init(); err != nil {
+			return err
+		}
+	}
+
+	reload := func() error {
+		prev := p.m
+		if err := p.init(); err != nil {
+			// init clears p.m before opening the file; keep the last good
+			// contents so the next successful reload diffs against them
+			p.m = prev
+			return err
+		}
+		return nil
+	}
+	return watchFile(ctx, p.filename, reload, func() (map[string][]string, error) {
+		return p.m, nil
+	}, fn)
+}
+
 // PlainFlagSource is a source that uses a flag value to define the config file to
 // pull configuration from
 type PlainFlagSource struct {
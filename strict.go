@@ -0,0 +1,58 @@
+package fc
+
+import (
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ParseStrict behaves like ParseArgs, but afterwards asks every source that
+// implements KeyLister to enumerate its keys, and fails with an error
+// listing every key that does not correspond to a flag defined in fs. This
+// catches typos in JSON/YAML/env configuration that ParseArgs would
+// otherwise silently ignore.
+func ParseStrict(args []string, fs *flag.FlagSet, sources ...Source) error {
+	if err := ParseArgs(args, fs, sources...); err != nil {
+		return err
+	}
+
+	var unknown []string
+	for _, source := range sources {
+		lister, ok := source.(KeyLister)
+		if !ok {
+			continue
+		}
+		keys, err := lister.Keys()
+		if err != nil {
+			return errors.Wrapf(err, "error listing keys for %s", source.Name())
+		}
+
+		valid := map[string]bool{}
+		mapper, hasMapper := source.(KeyMapper)
+		fs.VisitAll(func(f *flag.Flag) {
+			if hasMapper {
+				for _, k := range mapper.MappedKeys(f.Name) {
+					valid[k] = true
+				}
+				return
+			}
+			valid[f.Name] = true
+		})
+
+		for _, key := range keys {
+			if !valid[key] {
+				unknown = append(unknown, fmt.Sprintf("%s (%s)", key, source.Loc(key)))
+			}
+		}
+	}
+
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return errors.Errorf("unknown configuration key(s): %s", strings.Join(unknown, ", "))
+	}
+
+	return nil
+}
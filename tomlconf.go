@@ -0,0 +1,141 @@
+package fc
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/BurntSushi/toml"
+	"github.com/pkg/errors"
+)
+
+// TOMLSource is a source for config files in TOML format. Input should be a
+// table at the top level. The table's keys are treated as flag names, and
+// its values as flag values. If a value is an array, the flag will be set
+// multiple times. Nested tables are flattened to dotted key paths (e.g.
+// "server.port"), so a flag named "server.port" can be addressed as
+// "-server.port".
+type TOMLSource struct {
+	filename string
+	m        map[string]interface{}
+}
+
+// WithTOMLFile defines a new configuration source from the specified TOML
+// file
+func WithTOMLFile(filename string) *TOMLSource {
+	return &TOMLSource{
+		filename: filename,
+	}
+}
+
+// Name returns a useful name for the TOML config source for usage
+func (t *TOMLSource) Name() string {
+	return fmt.Sprintf("TOML configuration file %q", t.filename)
+}
+
+// Loc returns the table key where the value is expected to be found
+func (t *TOMLSource) Loc(key string) string {
+	return fmt.Sprintf("%s, key %q", t.filename, key)
+}
+
+// Get returns the stringified value stored at the specified key in the
+// TOML file
+func (t *TOMLSource) Get(key string) ([]string, error) {
+	if t.m == nil {
+		if err := t.init(); err != nil {
+			return nil, err
+		}
+	}
+	val, ok := t.m[key]
+	if !ok {
+		return nil, ErrMissing
+	}
+	values, err := stringifySlice(val)
+	if err != nil {
+		return nil, errors.Wrap(err, "error parsing TOML config")
+	}
+	return values, nil
+}
+
+func (t *TOMLSource) init() error {
+	tf, err := os.Open(t.filename)
+	if err != nil {
+		return err
+	}
+	defer tf.Close()
+
+	raw := make(map[string]interface{})
+	if _, err := toml.NewDecoder(tf).Decode(&raw); err != nil {
+		return errors.Wrap(err, "error parsing TOML config")
+	}
+
+	m := make(map[string]interface{})
+	flattenMap("", raw, m)
+	t.m = m
+	return nil
+}
+
+// Keys returns every key held by the TOML document, implementing KeyLister
+func (t *TOMLSource) Keys() ([]string, error) {
+	if t.m == nil {
+		if err := t.init(); err != nil {
+			return nil, err
+		}
+	}
+	keys := make([]string, 0, len(t.m))
+	for k := range t.m {
+		keys = append(keys, k)
+	}
+	return keys, nil
+}
+
+// Watch implements Watchable, reloading the TOML file whenever it changes
+// on disk and invoking fn for every key whose value changed
+func (t *TOMLSource) Watch(ctx context.Context, fn WatchFunc) error {
+	if t.m == nil {
+		if err := t.init(); err != nil {
+			return err
+		}
+	}
+
+	return watchFile(ctx, t.filename, t.init, func() (map[string][]string, error) {
+		return stringifyMap(t.m)
+	}, fn)
+}
+
+// TOMLFlagSource is a TOMLSource that uses a flag value to define the file
+// to pull configuration from
+type TOMLFlagSource struct {
+	*TOMLSource
+	flagName string
+}
+
+// WithTOMLFileFlag defines a new configuration source from the TOML
+// filename provided by the specified flag
+func WithTOMLFileFlag(flag string) *TOMLFlagSource {
+	return &TOMLFlagSource{
+		TOMLSource: &TOMLSource{},
+		flagName:   flag,
+	}
+}
+
+// Name returns a useful name for the TOML flag source for usage
+func (tf *TOMLFlagSource) Name() string {
+	return fmt.Sprintf("TOML configuration file defined by %q flag", tf.flagName)
+}
+
+// FlagNeeded returns the name of the flag that the TOMLFlagSource will use
+// to determine which file to pull configuration from
+func (tf *TOMLFlagSource) FlagNeeded() string {
+	return tf.flagName
+}
+
+// WithFlagValue will set the filename the TOMLFlagSource will pull
+// configuration from
+func (tf *TOMLFlagSource) WithFlagValue(value string) error {
+	tf.TOMLSource.filename = value
+	if value == "" {
+		return errors.New("TOMLFlagSource given an empty string")
+	}
+	return nil
+}
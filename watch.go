@@ -0,0 +1,206 @@
+package fc
+
+import (
+	"context"
+	"flag"
+	"path/filepath"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/pkg/errors"
+)
+
+// WatchFunc is invoked when a watched source detects a change to one of its
+// keys. oldVals and newVals are the previous and current stringified
+// values for the key, as would be returned by Source.Get; newVals is nil if
+// the key was removed.
+type WatchFunc func(key string, oldVals, newVals []string)
+
+// Resettable is an optional interface a flag.Value can implement to support
+// being cleared before Watch reapplies a changed key's reloaded values. The
+// conventional multi-value flag.Value accumulates on every Set call — it's
+// what lets "-tag a -tag b" populate a slice from repeated command-line
+// flags — but that same behavior means reapplying a reload's values on top
+// of the previous reload's appends the new values instead of replacing
+// them. A flag.Value that implements Resettable is cleared first so newVals
+// ends up as the whole value; one that doesn't is left to accumulate, so
+// only scalar (overwrite-on-Set) flags are safe to watch without it.
+type Resettable interface {
+	Reset()
+}
+
+// Watchable is an optional interface a Source can implement to support
+// hot-reloading. Watch should watch the source's backing file for changes
+// and invoke fn for every key whose value changed, until ctx is canceled.
+type Watchable interface {
+	// Watch watches the source for changes, invoking fn for every key whose
+	// value changes, until ctx is canceled
+	Watch(ctx context.Context, fn WatchFunc) error
+}
+
+// Watch starts watching every source that implements Watchable. Whenever a
+// watched source reports a changed key, its new value is applied to the
+// matching flag in fs, unless that flag was already set on the command
+// line (as recorded by ParseArgs, or reported by fs.Visit for a FlagSet
+// not parsed through this package) — command-line values always take
+// precedence. If the flag's Value implements Resettable, it is cleared
+// before the new values are applied, so a multi-value flag is replaced
+// rather than appended to; see Resettable for flags that don't. For every
+// such flag, onChange is then invoked with the key and its old and new
+// values; onChange may be nil. Watch blocks until ctx is canceled.
+func Watch(ctx context.Context, fs *flag.FlagSet, onChange WatchFunc, sources ...Source) error {
+	// Prefer the command-line-only snapshot ParseArgs/ParseStrict took
+	// before applying source values, since by now fs.Visit can no longer
+	// tell those apart from a config-sourced value. Fall back to fs.Visit
+	// for a FlagSet that wasn't parsed through this package.
+	setByUser := cmdlineFlags[fs]
+	if setByUser == nil {
+		setByUser = map[string]bool{}
+		fs.Visit(func(f *flag.Flag) {
+			setByUser[f.Name] = true
+		})
+	}
+
+	for _, source := range sources {
+		watchable, ok := source.(Watchable)
+		if !ok {
+			continue
+		}
+		if err := watchable.Watch(ctx, func(key string, oldVals, newVals []string) {
+			if setByUser[key] {
+				return
+			}
+			target := fs.Lookup(key)
+			if target == nil {
+				return
+			}
+			if r, ok := target.Value.(Resettable); ok {
+				r.Reset()
+			}
+			for _, v := range newVals {
+				_ = fs.Set(key, v)
+			}
+			if onChange != nil {
+				onChange(key, oldVals, newVals)
+			}
+		}); err != nil {
+			return errors.Wrapf(err, "error watching %s", source.Name())
+		}
+	}
+
+	<-ctx.Done()
+	return nil
+}
+
+// watchFile is the shared body behind every file-backed Source's Watch
+// method (PlainSource, JSONSource, YAMLSource, TOMLSource). It watches
+// filename for changes, and on each one calls reloadFn to refresh the
+// source's in-memory state and snapshotFn, before and after, to capture a
+// diffable view of it, forwarding every changed key to fn.
+//
+// The directory containing filename is watched rather than filename
+// itself, and events are filtered down to its base name. An fsnotify watch
+// follows the inode it was added for, so watching filename directly stops
+// delivering events the moment that inode is replaced — which is exactly
+// what an atomic config deploy does: a Kubernetes ConfigMap update, a
+// consul-template render, and a plain `mv` into place all land the new
+// content by renaming a file over the old one, or by swapping a symlink.
+// Watching the parent directory survives that, since the directory entry
+// fsnotify is watching never goes away.
+func watchFile(ctx context.Context, filename string, reloadFn func() error, snapshotFn func() (map[string][]string, error), fn WatchFunc) error {
+	dir := filepath.Dir(filename)
+	base := filepath.Base(filename)
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	if err := watcher.Add(dir); err != nil {
+		watcher.Close()
+		return err
+	}
+
+	go func() {
+		defer watcher.Close()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if filepath.Base(event.Name) != base {
+					continue
+				}
+				if event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Remove|fsnotify.Rename) == 0 {
+					continue
+				}
+				old, err := snapshotFn()
+				if err != nil {
+					continue
+				}
+				if err := reloadFn(); err != nil {
+					// The rename/remove half of an atomic replace can
+					// land us here with the old content momentarily
+					// gone; the create half that follows will trigger
+					// a successful reload.
+					continue
+				}
+				new, err := snapshotFn()
+				if err != nil {
+					continue
+				}
+				diffStringSliceMaps(old, new, fn)
+			case _, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+			}
+		}
+	}()
+
+	return nil
+}
+
+// diffStringSliceMaps compares old and new, invoking fn for every key whose
+// values differ, including keys removed in new
+func diffStringSliceMaps(old, new map[string][]string, fn WatchFunc) {
+	seen := make(map[string]bool, len(new))
+	for k, newVals := range new {
+		seen[k] = true
+		if oldVals := old[k]; !equalStringSlices(oldVals, newVals) {
+			fn(k, oldVals, newVals)
+		}
+	}
+	for k, oldVals := range old {
+		if !seen[k] {
+			fn(k, oldVals, nil)
+		}
+	}
+}
+
+func equalStringSlices(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// stringifyMap converts a map of raw decoded values (as found on YAML and
+// TOML sources) to their stringified form, for diffing across a reload
+func stringifyMap(m map[string]interface{}) (map[string][]string, error) {
+	out := make(map[string][]string, len(m))
+	for k, v := range m {
+		vals, err := stringifySlice(v)
+		if err != nil {
+			return nil, err
+		}
+		out[k] = vals
+	}
+	return out, nil
+}
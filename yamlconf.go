@@ -0,0 +1,141 @@
+package fc
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// YAMLSource is a source for config files in YAML format. Input should be a
+// mapping at the top level. The mapping's keys are treated as flag names,
+// and its values as flag values. If a value is a sequence, the flag will be
+// set multiple times. Nested mappings are flattened to dotted key paths
+// (e.g. "server.port"), so a flag named "server.port" can be addressed as
+// "-server.port".
+type YAMLSource struct {
+	filename string
+	m        map[string]interface{}
+}
+
+// WithYAMLFile defines a new configuration source from the specified YAML
+// file
+func WithYAMLFile(filename string) *YAMLSource {
+	return &YAMLSource{
+		filename: filename,
+	}
+}
+
+// Name returns a useful name for the YAML config source for usage
+func (y *YAMLSource) Name() string {
+	return fmt.Sprintf("YAML configuration file %q", y.filename)
+}
+
+// Loc returns the object key where the value is expected to be found
+func (y *YAMLSource) Loc(key string) string {
+	return fmt.Sprintf("%s, key %q", y.filename, key)
+}
+
+// Get returns the stringified value stored at the specified key in the YAML
+// file
+func (y *YAMLSource) Get(key string) ([]string, error) {
+	if y.m == nil {
+		if err := y.init(); err != nil {
+			return nil, err
+		}
+	}
+	val, ok := y.m[key]
+	if !ok {
+		return nil, ErrMissing
+	}
+	values, err := stringifySlice(val)
+	if err != nil {
+		return nil, errors.Wrap(err, "error parsing YAML config")
+	}
+	return values, nil
+}
+
+func (y *YAMLSource) init() error {
+	yf, err := os.Open(y.filename)
+	if err != nil {
+		return err
+	}
+	defer yf.Close()
+
+	raw := make(map[string]interface{})
+	if err := yaml.NewDecoder(yf).Decode(&raw); err != nil {
+		return errors.Wrap(err, "error parsing YAML config")
+	}
+
+	m := make(map[string]interface{})
+	flattenMap("", raw, m)
+	y.m = m
+	return nil
+}
+
+// Keys returns every key held by the YAML document, implementing KeyLister
+func (y *YAMLSource) Keys() ([]string, error) {
+	if y.m == nil {
+		if err := y.init(); err != nil {
+			return nil, err
+		}
+	}
+	keys := make([]string, 0, len(y.m))
+	for k := range y.m {
+		keys = append(keys, k)
+	}
+	return keys, nil
+}
+
+// Watch implements Watchable, reloading the YAML file whenever it changes
+// on disk and invoking fn for every key whose value changed
+func (y *YAMLSource) Watch(ctx context.Context, fn WatchFunc) error {
+	if y.m == nil {
+		if err := y.init(); err != nil {
+			return err
+		}
+	}
+
+	return watchFile(ctx, y.filename, y.init, func() (map[string][]string, error) {
+		return stringifyMap(y.m)
+	}, fn)
+}
+
+// YAMLFlagSource is a YAMLSource that uses a flag value to define the file
+// to pull configuration from
+type YAMLFlagSource struct {
+	*YAMLSource
+	flagName string
+}
+
+// WithYAMLFileFlag defines a new configuration source from the YAML
+// filename provided by the specified flag
+func WithYAMLFileFlag(flag string) *YAMLFlagSource {
+	return &YAMLFlagSource{
+		YAMLSource: &YAMLSource{},
+		flagName:   flag,
+	}
+}
+
+// Name returns a useful name for the YAML flag source for usage
+func (yf *YAMLFlagSource) Name() string {
+	return fmt.Sprintf("YAML configuration file defined by %q flag", yf.flagName)
+}
+
+// FlagNeeded returns the name of the flag that the YAMLFlagSource will use
+// to determine which file to pull configuration from
+func (yf *YAMLFlagSource) FlagNeeded() string {
+	return yf.flagName
+}
+
+// WithFlagValue will set the filename the YAMLFlagSource will pull
+// configuration from
+func (yf *YAMLFlagSource) WithFlagValue(value string) error {
+	yf.YAMLSource.filename = value
+	if value == "" {
+		return errors.New("YAMLFlagSource given an empty string")
+	}
+	return nil
+}